@@ -0,0 +1,45 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LoadTicks reads a CSV file of historical top-of-book data with
+// columns "bid,ask" (an optional header row starting with a
+// non-numeric field is skipped).
+func LoadTicks(path string) ([]Tick, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	ticks := make([]Tick, 0, len(rows))
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("backtest: row %d: expected at least 2 columns, got %d", i, len(row))
+		}
+		bid, err := strconv.ParseFloat(row[0], 64)
+		if err != nil {
+			if i == 0 {
+				continue // header row
+			}
+			return nil, fmt.Errorf("backtest: row %d: %w", i, err)
+		}
+		ask, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("backtest: row %d: %w", i, err)
+		}
+		ticks = append(ticks, Tick{Bid: bid, Ask: ask})
+	}
+	return ticks, nil
+}
@@ -0,0 +1,116 @@
+package backtest
+
+import (
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/strategy"
+)
+
+// Run replays strat tick-by-tick against sim: each tick it asks the
+// strategy to decide, places any resulting orders directly against the
+// simulator, advances to the next tick (running the matching engine),
+// and feeds terminal order states back via OnOrderUpdate.
+//
+// A resting order is left on the book across ticks until it either
+// fills or the strategy itself decides to post a new set on top of it;
+// in the latter case Run cancels the stale orders first and reports
+// them to strat as cancelled, so any internal gating state (e.g.
+// strategy.PingPong's hasOrder) it keeps stays in sync with what's
+// actually resting. This only triggers for a strategy that doesn't
+// self-gate its re-quoting; the built-ins all do.
+func Run(strat strategy.Strategy, sim *Simulator) error {
+	seenFills := 0
+	var resting []string
+
+	for !sim.Done() {
+		bids, asks, err := sim.OrderBook(sim.pair)
+		if err != nil {
+			return err
+		}
+		ratio, err := sim.InventoryRatio((bids[0].Price + asks[0].Price) / 2)
+		if err != nil {
+			return err
+		}
+		md := strategy.MarketData{Bid: bids[0].Price, Ask: asks[0].Price, Spread: asks[0].Price - bids[0].Price, InventoryRatio: ratio}
+
+		intents, err := strat.OnTick(md)
+		if err != nil {
+			return err
+		}
+
+		if len(intents) > 0 {
+			if err := cancelResting(sim, strat, resting); err != nil {
+				return err
+			}
+			resting = nil
+		}
+
+		for _, intent := range intents {
+			id, err := sim.PostOrder(sim.pair, intent.Type, intent.Volume, intent.Price)
+			if err != nil {
+				return err
+			}
+			resting = append(resting, id)
+		}
+
+		if err := sim.Advance(); err != nil {
+			return err
+		}
+
+		fills := sim.Fills()
+		for _, fill := range fills[seenFills:] {
+			order, err := sim.GetOrder(fill.OrderId)
+			if err != nil {
+				return err
+			}
+			if err := strat.OnOrderUpdate(order); err != nil {
+				return err
+			}
+		}
+		seenFills = len(fills)
+
+		resting, err = stillOpen(sim, resting)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelResting stops every order in ids that hasn't already reached
+// bitx.Complete and reports it to strat via OnOrderUpdate, so gating
+// state tracked by the strategy reflects the cancellation.
+func cancelResting(sim *Simulator, strat strategy.Strategy, ids []string) error {
+	for _, id := range ids {
+		order, err := sim.GetOrder(id)
+		if err != nil {
+			return err
+		}
+		if order.State == bitx.Complete {
+			continue
+		}
+		if _, err := sim.StopOrder(id); err != nil {
+			return err
+		}
+		if err := strat.OnOrderUpdate(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stillOpen filters ids down to the orders that haven't reached
+// bitx.Complete, so a later cancelResting pass doesn't re-examine ones
+// already resolved via the fills loop above.
+func stillOpen(sim *Simulator, ids []string) ([]string, error) {
+	open := ids[:0]
+	for _, id := range ids {
+		order, err := sim.GetOrder(id)
+		if err != nil {
+			return nil, err
+		}
+		if order.State != bitx.Complete {
+			open = append(open, id)
+		}
+	}
+	return open, nil
+}
@@ -0,0 +1,197 @@
+// Package backtest implements the bot's client.Client interface against
+// historical order-book data, backed by a simple matching engine, so a
+// strategy can be replayed over a date range before going live.
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/bitx/bitx-go"
+)
+
+// Tick is one row of historical top-of-book data.
+type Tick struct {
+	Bid, Ask float64
+}
+
+// Fees holds maker/taker fee rates for simulated fills. Every fill is
+// charged TakerRate; MakerRate is not applied.
+type Fees struct {
+	MakerRate float64
+	TakerRate float64
+}
+
+// Fill records one simulated execution.
+type Fill struct {
+	OrderId string
+	Type    bitx.OrderType
+	Price   float64
+	Volume  float64
+	Fee     float64
+	Tick    int
+}
+
+// Simulator implements client.Client against a pre-loaded slice of
+// Ticks. Orders fill when the historical top of book crosses the posted
+// price: a BID fills once Ask <= Price, an ASK fills once Bid >= Price.
+// The mid price is not used for matching.
+type Simulator struct {
+	pair string
+	fees Fees
+
+	ticks []Tick
+	pos   int
+
+	balances map[string]float64
+	orders   map[string]*bitx.Order
+	nextId   int
+
+	fills []Fill
+}
+
+// NewSimulator constructs a Simulator over ticks, seeded with the given
+// starting balances (keyed by asset code, e.g. "XBT", "ZAR").
+func NewSimulator(pair string, ticks []Tick, fees Fees, startingBalances map[string]float64) *Simulator {
+	balances := make(map[string]float64, len(startingBalances))
+	for asset, bal := range startingBalances {
+		balances[asset] = bal
+	}
+	return &Simulator{
+		pair:     pair,
+		fees:     fees,
+		ticks:    ticks,
+		balances: balances,
+		orders:   make(map[string]*bitx.Order),
+	}
+}
+
+// Done reports whether every historical tick has been consumed.
+func (s *Simulator) Done() bool {
+	return s.pos >= len(s.ticks)
+}
+
+// Advance moves to the next historical tick, running the matching
+// engine against all open orders.
+func (s *Simulator) Advance() error {
+	if s.Done() {
+		return fmt.Errorf("backtest: no more historical data")
+	}
+	tick := s.ticks[s.pos]
+	s.pos++
+
+	for id, order := range s.orders {
+		if order.State == bitx.Complete {
+			continue
+		}
+
+		crossed := false
+		switch order.Type {
+		case bitx.BID:
+			crossed = tick.Ask <= order.Price
+		case bitx.ASK:
+			crossed = tick.Bid >= order.Price
+		}
+		if !crossed {
+			continue
+		}
+
+		s.fill(id, order, s.pos-1)
+	}
+	return nil
+}
+
+func (s *Simulator) fill(id string, order *bitx.Order, tickIdx int) {
+	base, quote := assetsOf(s.pair)
+	notional := order.Price * order.Volume
+	fee := notional * s.fees.TakerRate
+
+	switch order.Type {
+	case bitx.BID:
+		s.balances[base] += order.Volume
+		s.balances[quote] -= notional + fee
+	case bitx.ASK:
+		s.balances[base] -= order.Volume
+		s.balances[quote] += notional - fee
+	}
+
+	order.State = bitx.Complete
+	s.fills = append(s.fills, Fill{OrderId: id, Type: order.Type, Price: order.Price, Volume: order.Volume, Fee: fee, Tick: tickIdx})
+}
+
+// assetsOf splits a pair like "XBTZAR" into its base and quote assets,
+// mirroring how the bot derives the quote asset from *Pair elsewhere.
+func assetsOf(pair string) (base, quote string) {
+	if len(pair) != 6 {
+		return pair, ""
+	}
+	return pair[:3], pair[3:]
+}
+
+// Balance implements client.Client.
+func (s *Simulator) Balance(asset string) (balance, reserved float64, err error) {
+	return s.balances[asset], 0, nil
+}
+
+// InventoryRatio returns the base asset's share of total portfolio
+// value, marked at mid, mirroring how the live bot derives
+// strategy.MarketData.InventoryRatio from account balances.
+func (s *Simulator) InventoryRatio(mid float64) (float64, error) {
+	base, quote := assetsOf(s.pair)
+	baseValue := s.balances[base] * mid
+	total := baseValue + s.balances[quote]
+	if total == 0 {
+		return 0, nil
+	}
+	return baseValue / total, nil
+}
+
+// OrderBook implements client.Client against the current tick.
+func (s *Simulator) OrderBook(pair string) (bids, asks []bitx.PriceVolume, err error) {
+	if s.Done() {
+		return nil, nil, fmt.Errorf("backtest: no more historical data")
+	}
+	tick := s.ticks[s.pos]
+	return []bitx.PriceVolume{{Price: tick.Bid}}, []bitx.PriceVolume{{Price: tick.Ask}}, nil
+}
+
+// PostOrder implements client.Client.
+func (s *Simulator) PostOrder(pair string, orderType bitx.OrderType, volume, price float64) (orderId string, err error) {
+	s.nextId++
+	id := fmt.Sprintf("backtest-%d", s.nextId)
+	s.orders[id] = &bitx.Order{Id: id, Type: orderType, Price: price, Volume: volume, State: bitx.Pending}
+	return id, nil
+}
+
+// GetOrder implements client.Client.
+func (s *Simulator) GetOrder(id string) (*bitx.Order, error) {
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("backtest: unknown order %s", id)
+	}
+	return order, nil
+}
+
+// ListOrders implements client.Client.
+func (s *Simulator) ListOrders(pair string) ([]bitx.Order, error) {
+	orders := make([]bitx.Order, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, *order)
+	}
+	return orders, nil
+}
+
+// StopOrder implements client.Client.
+func (s *Simulator) StopOrder(id string) (bool, error) {
+	order, ok := s.orders[id]
+	if !ok {
+		return false, fmt.Errorf("backtest: unknown order %s", id)
+	}
+	delete(s.orders, id)
+	_ = order
+	return true, nil
+}
+
+// Fills returns every simulated execution so far.
+func (s *Simulator) Fills() []Fill {
+	return s.fills
+}
@@ -0,0 +1,77 @@
+package backtest
+
+import (
+	"testing"
+
+	"github.com/bitx/bitx-go"
+)
+
+func TestSimulatorFillsBidWhenAskCrosses(t *testing.T) {
+	ticks := []Tick{
+		{Bid: 99, Ask: 101},
+		{Bid: 99, Ask: 100}, // ask drops to meet a BID posted at 100
+	}
+	sim := NewSimulator("XBTZAR", ticks, Fees{TakerRate: 0.001}, map[string]float64{"XBT": 0, "ZAR": 10000})
+
+	id, err := sim.PostOrder("XBTZAR", bitx.BID, 1, 100)
+	if err != nil {
+		t.Fatalf("PostOrder returned error: %s", err)
+	}
+
+	if err := sim.Advance(); err != nil {
+		t.Fatalf("Advance returned error: %s", err)
+	}
+	if order, _ := sim.GetOrder(id); order.State == bitx.Complete {
+		t.Fatalf("order filled before its price was crossed")
+	}
+
+	if err := sim.Advance(); err != nil {
+		t.Fatalf("Advance returned error: %s", err)
+	}
+	order, err := sim.GetOrder(id)
+	if err != nil {
+		t.Fatalf("GetOrder returned error: %s", err)
+	}
+	if order.State != bitx.Complete {
+		t.Fatalf("expected order to be filled once ask crossed its price, got state %v", order.State)
+	}
+
+	base, _, _ := sim.Balance("XBT")
+	if base != 1 {
+		t.Errorf("expected 1 XBT credited after fill, got %f", base)
+	}
+	quote, _, _ := sim.Balance("ZAR")
+	wantQuote := 10000 - 100 - 100*0.001
+	if quote != wantQuote {
+		t.Errorf("expected ZAR balance %f after fill and fee, got %f", wantQuote, quote)
+	}
+}
+
+func TestSimulatorStopOrderRemovesUnfilledOrder(t *testing.T) {
+	ticks := []Tick{{Bid: 99, Ask: 101}}
+	sim := NewSimulator("XBTZAR", ticks, Fees{}, map[string]float64{"XBT": 0, "ZAR": 10000})
+
+	id, err := sim.PostOrder("XBTZAR", bitx.BID, 1, 50)
+	if err != nil {
+		t.Fatalf("PostOrder returned error: %s", err)
+	}
+
+	if ok, err := sim.StopOrder(id); err != nil || !ok {
+		t.Fatalf("StopOrder returned (%v, %v)", ok, err)
+	}
+	if _, err := sim.GetOrder(id); err == nil {
+		t.Errorf("expected GetOrder to fail for a stopped order")
+	}
+}
+
+func TestSimulatorInventoryRatio(t *testing.T) {
+	sim := NewSimulator("XBTZAR", nil, Fees{}, map[string]float64{"XBT": 1, "ZAR": 100000})
+
+	ratio, err := sim.InventoryRatio(100000)
+	if err != nil {
+		t.Fatalf("InventoryRatio returned error: %s", err)
+	}
+	if want := 0.5; ratio != want {
+		t.Errorf("expected an even split to report ratio %f, got %f", want, ratio)
+	}
+}
@@ -0,0 +1,152 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bitx/bitx-go"
+)
+
+// Summary is the run report produced at the end of a backtest.
+type Summary struct {
+	Trades        int
+	WinRate       float64
+	RealizedPnL   float64
+	UnrealizedPnL float64
+	Sharpe        float64
+	MaxDrawdown   float64
+}
+
+// Summarize computes a run Summary from the Simulator's fills.
+// RealizedPnL is the gain/loss already locked in by sells against their
+// average cost basis; UnrealizedPnL is the mark-to-market gain/loss on
+// whatever base-asset inventory is still held, valued at the final
+// tick's mid price.
+func (s *Simulator) Summarize(pair string, startingBalances map[string]float64) Summary {
+	base, quote := assetsOf(pair)
+
+	equity := make([]float64, 0, len(s.fills)+1)
+	runningQuote := startingBalances[quote]
+	runningBase := startingBalances[base]
+	equity = append(equity, runningQuote+runningBase*midAt(s.ticks, 0))
+
+	position := startingBalances[base]
+	costBasis := midAt(s.ticks, 0)
+	realized := 0.0
+
+	wins := 0
+	for _, fill := range s.fills {
+		notional := fill.Price * fill.Volume
+		switch fill.Type {
+		case bitx.BID:
+			totalCost := position*costBasis + notional + fill.Fee
+			position += fill.Volume
+			if position != 0 {
+				costBasis = totalCost / position
+			}
+			runningBase += fill.Volume
+			runningQuote -= notional + fill.Fee
+			if midAt(s.ticks, fill.Tick) > fill.Price {
+				wins++
+			}
+		case bitx.ASK:
+			realized += fill.Volume*(fill.Price-costBasis) - fill.Fee
+			position -= fill.Volume
+			runningBase -= fill.Volume
+			runningQuote += notional - fill.Fee
+			if midAt(s.ticks, fill.Tick) < fill.Price {
+				wins++
+			}
+		}
+		equity = append(equity, runningQuote+runningBase*midAt(s.ticks, fill.Tick))
+	}
+
+	finalMid := midAt(s.ticks, len(s.ticks)-1)
+	unrealized := position * (finalMid - costBasis)
+
+	winRate := 0.0
+	if len(s.fills) > 0 {
+		winRate = float64(wins) / float64(len(s.fills))
+	}
+
+	return Summary{
+		Trades:        len(s.fills),
+		WinRate:       winRate,
+		RealizedPnL:   realized,
+		UnrealizedPnL: unrealized,
+		Sharpe:        sharpe(equity),
+		MaxDrawdown:   maxDrawdown(equity),
+	}
+}
+
+func midAt(ticks []Tick, i int) float64 {
+	if i < 0 || i >= len(ticks) {
+		return 0
+	}
+	return (ticks[i].Bid + ticks[i].Ask) / 2
+}
+
+// sharpe computes the Sharpe ratio of the equity curve's step returns,
+// assuming a zero risk-free rate.
+func sharpe(equity []float64) float64 {
+	if len(equity) < 2 {
+		return 0
+	}
+
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1] == 0 {
+			continue
+		}
+		returns = append(returns, (equity[i]-equity[i-1])/equity[i-1])
+	}
+	if len(returns) == 0 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns))
+
+	stddev := math.Sqrt(variance)
+	if stddev == 0 {
+		return 0
+	}
+	return mean / stddev
+}
+
+// maxDrawdown returns the largest peak-to-trough decline in the equity
+// curve, expressed as a positive fraction.
+func maxDrawdown(equity []float64) float64 {
+	peak := 0.0
+	worst := 0.0
+	for i, v := range equity {
+		if i == 0 || v > peak {
+			peak = v
+		}
+		if peak == 0 {
+			continue
+		}
+		drawdown := (peak - v) / peak
+		if drawdown > worst {
+			worst = drawdown
+		}
+	}
+	return worst
+}
+
+// String renders the Summary for console output.
+func (sum Summary) String() string {
+	return fmt.Sprintf(
+		"Trades: %d\nWin rate: %.2f%%\nRealized PnL: %f\nUnrealized PnL: %f\nSharpe: %.4f\nMax drawdown: %.2f%%",
+		sum.Trades, sum.WinRate*100, sum.RealizedPnL, sum.UnrealizedPnL, sum.Sharpe, sum.MaxDrawdown*100,
+	)
+}
@@ -0,0 +1,72 @@
+package backtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitx/bitx-go"
+)
+
+func TestSummarizeRealizedPnLFromClosedTrade(t *testing.T) {
+	ticks := []Tick{
+		{Bid: 99, Ask: 100},  // ask meets a BID posted at 100
+		{Bid: 120, Ask: 122}, // bid meets an ASK posted at 120
+		{Bid: 119, Ask: 121},
+	}
+	sim := NewSimulator("XBTZAR", ticks, Fees{}, map[string]float64{"XBT": 0, "ZAR": 10000})
+
+	if _, err := sim.PostOrder("XBTZAR", bitx.BID, 1, 100); err != nil {
+		t.Fatalf("PostOrder returned error: %s", err)
+	}
+	if err := sim.Advance(); err != nil {
+		t.Fatalf("Advance returned error: %s", err)
+	}
+
+	if _, err := sim.PostOrder("XBTZAR", bitx.ASK, 1, 120); err != nil {
+		t.Fatalf("PostOrder returned error: %s", err)
+	}
+	if err := sim.Advance(); err != nil {
+		t.Fatalf("Advance returned error: %s", err)
+	}
+
+	sum := sim.Summarize("XBTZAR", map[string]float64{"XBT": 0, "ZAR": 10000})
+	if sum.Trades != 2 {
+		t.Fatalf("expected 2 trades, got %d", sum.Trades)
+	}
+	if want := 20.0; sum.RealizedPnL != want {
+		t.Errorf("expected realized PnL of %f from buying at 100 and selling at 120, got %f", want, sum.RealizedPnL)
+	}
+	if sum.UnrealizedPnL != 0 {
+		t.Errorf("expected no unrealized PnL once the position is fully closed, got %f", sum.UnrealizedPnL)
+	}
+}
+
+func TestSummarizeUnrealizedPnLOnOpenPosition(t *testing.T) {
+	ticks := []Tick{
+		{Bid: 99, Ask: 100},  // ask meets a BID posted at 100
+		{Bid: 109, Ask: 111}, // final mid 110, position stays open
+	}
+	sim := NewSimulator("XBTZAR", ticks, Fees{}, map[string]float64{"XBT": 0, "ZAR": 10000})
+
+	if _, err := sim.PostOrder("XBTZAR", bitx.BID, 1, 100); err != nil {
+		t.Fatalf("PostOrder returned error: %s", err)
+	}
+	if err := sim.Advance(); err != nil {
+		t.Fatalf("Advance returned error: %s", err)
+	}
+
+	sum := sim.Summarize("XBTZAR", map[string]float64{"XBT": 0, "ZAR": 10000})
+	if sum.RealizedPnL != 0 {
+		t.Errorf("expected no realized PnL before any closing trade, got %f", sum.RealizedPnL)
+	}
+	if want := 10.0; sum.UnrealizedPnL != want {
+		t.Errorf("expected unrealized PnL of %f marking 1 XBT bought at 100 to a final mid of 110, got %f", want, sum.UnrealizedPnL)
+	}
+}
+
+func TestSummaryStringIncludesUnrealizedPnL(t *testing.T) {
+	sum := Summary{UnrealizedPnL: 42}
+	if got := sum.String(); !strings.Contains(got, "Unrealized PnL: 42.000000") {
+		t.Errorf("expected String() to report UnrealizedPnL, got %q", got)
+	}
+}
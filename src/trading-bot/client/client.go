@@ -0,0 +1,16 @@
+// Package client declares the subset of the bitx-go Client API the bot
+// depends on, so alternative implementations (the backtest simulator)
+// can stand in for a live connection.
+package client
+
+import "github.com/bitx/bitx-go"
+
+// Client is satisfied by both *bitx.Client and *backtest.Simulator.
+type Client interface {
+	Balance(asset string) (balance, reserved float64, err error)
+	OrderBook(pair string) (bids, asks []bitx.PriceVolume, err error)
+	PostOrder(pair string, orderType bitx.OrderType, volume, price float64) (orderId string, err error)
+	GetOrder(id string) (*bitx.Order, error)
+	ListOrders(pair string) ([]bitx.Order, error)
+	StopOrder(id string) (bool, error)
+}
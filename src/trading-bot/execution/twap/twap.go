@@ -0,0 +1,219 @@
+// Package twap implements time-weighted average price order slicing:
+// a large parent order is split into smaller child orders posted near
+// the top of book over a fixed duration, reducing market impact versus
+// posting the whole size at once.
+package twap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/client"
+	"github.com/bitx/trading-bot/executor"
+	"github.com/bitx/trading-bot/stream"
+)
+
+// tickSize is the price increment used to post one or more ticks inside
+// the spread, matching the "bid+1"/"ask-1" convention used elsewhere in
+// the bot.
+const tickSize = 1.0
+
+// Config configures a single TWAP execution run.
+type Config struct {
+	Pair     string
+	Side     bitx.OrderType
+	Quantity float64
+	Duration time.Duration
+
+	// SliceInterval is how often the remaining quantity is re-sliced
+	// and reposted at the new top of book.
+	SliceInterval time.Duration
+
+	// NumberOfTicks is how many ticks inside the spread each child
+	// order is posted, e.g. 1 means one tick better than top of book.
+	NumberOfTicks float64
+
+	// PriceLimit aborts the execution if the market crosses it. Zero
+	// disables the guard.
+	PriceLimit float64
+}
+
+// Execution runs a Config's slice schedule against a live or simulated
+// client.
+type Execution struct {
+	cfg   Config
+	exec  *executor.OrderExecutor
+	book  *executor.ActiveOrderBook
+	books *stream.OrderBookStream
+	users *stream.UserDataStream
+
+	filled float64
+}
+
+// NewExecution constructs an Execution. Call Run to start it.
+func NewExecution(cfg Config, c client.Client) *Execution {
+	refresh := cfg.SliceInterval / 4
+	if refresh <= 0 {
+		refresh = time.Second
+	}
+
+	e := &Execution{
+		cfg:   cfg,
+		exec:  executor.NewOrderExecutor(c),
+		book:  executor.NewActiveOrderBook(),
+		books: stream.NewOrderBookStream(c, cfg.Pair, refresh),
+		users: stream.NewUserDataStream(c, cfg.Pair, refresh),
+	}
+	e.book.OnFilled = func(order *bitx.Order) {
+		e.filled += order.Volume
+	}
+	return e
+}
+
+// Filled returns the quantity executed so far.
+func (e *Execution) Filled() float64 {
+	return e.filled
+}
+
+// Run drives the slice schedule until Config.Quantity is filled,
+// Config.Duration elapses, ctx is cancelled, or PriceLimit is breached.
+// On any exit it cancels whatever child orders remain outstanding.
+func (e *Execution) Run(parent context.Context) error {
+	ctx, cancel := context.WithTimeout(parent, e.cfg.Duration)
+	defer cancel()
+
+	go e.books.Run(ctx)
+	go e.users.Run(ctx)
+	go func() {
+		for event := range e.users.Events() {
+			switch event.Type {
+			case stream.OrderFilled, stream.OrderUpdated:
+				e.book.Update(event.Order)
+			case stream.OrderCancelled:
+				e.book.Cancel(event.Order)
+			}
+		}
+	}()
+
+	sliceCount := int(e.cfg.Duration / e.cfg.SliceInterval)
+	if sliceCount < 1 {
+		sliceCount = 1
+	}
+	sliceQty := e.cfg.Quantity / float64(sliceCount)
+
+	ticker := time.NewTicker(e.cfg.SliceInterval)
+	defer ticker.Stop()
+
+	for e.filled < e.cfg.Quantity {
+		select {
+		case <-ctx.Done():
+			e.cancelOutstanding(context.Background())
+			if parent.Err() != nil {
+				return parent.Err()
+			}
+			return nil // Duration elapsed; not an error.
+
+		case md, open := <-e.books.Updates():
+			if !open {
+				continue
+			}
+			// Reacts to book moves between scheduled ticks so a
+			// PriceLimit breach aborts promptly rather than waiting
+			// for the next slice.
+			if err := e.checkPriceLimit(md); err != nil {
+				e.cancelOutstanding(context.Background())
+				return err
+			}
+
+		case <-ticker.C:
+			if err := e.cancelOutstanding(ctx); err != nil {
+				return err
+			}
+
+			remaining := e.cfg.Quantity - e.filled
+			if remaining <= 0 {
+				return nil
+			}
+			qty := sliceQty
+			if qty > remaining {
+				qty = remaining
+			}
+
+			md, ok := e.books.Latest()
+			if !ok {
+				continue
+			}
+			if err := e.checkPriceLimit(md); err != nil {
+				return err
+			}
+
+			order, err := e.postSlice(ctx, md, qty)
+			if err != nil {
+				return err
+			}
+			e.book.Add(order)
+			e.users.Track(order)
+		}
+	}
+	return nil
+}
+
+func (e *Execution) postSlice(ctx context.Context, md stream.BookUpdate, qty float64) (*bitx.Order, error) {
+	offset := e.cfg.NumberOfTicks * tickSize
+
+	var price float64
+	switch e.cfg.Side {
+	case bitx.BID:
+		price = md.Bid + offset
+	case bitx.ASK:
+		price = md.Ask - offset
+	}
+
+	return e.exec.SubmitOrder(ctx, executor.SubmitOrderRequest{
+		Pair:   e.cfg.Pair,
+		Type:   e.cfg.Side,
+		Price:  price,
+		Volume: qty,
+	})
+}
+
+// cancelOutstanding cancels every order still live in the
+// ActiveOrderBook, so the next slice posts a fresh order rather than
+// leaving stale remainders resting on the book. GracefulCancel's
+// terminal state only means "no longer open" — it can't tell a genuine
+// cancellation from a fill that raced ahead of the cancel — so each
+// final order is routed through Resolve rather than unconditionally
+// treated as cancelled, to avoid under-crediting e.filled.
+func (e *Execution) cancelOutstanding(ctx context.Context) error {
+	orders := e.book.Orders()
+	if len(orders) == 0 {
+		return nil
+	}
+	final, err := e.exec.GracefulCancel(ctx, orders...)
+	for _, order := range final {
+		if order != nil {
+			e.book.Resolve(order)
+		}
+	}
+	return err
+}
+
+func (e *Execution) checkPriceLimit(md stream.BookUpdate) error {
+	if e.cfg.PriceLimit == 0 {
+		return nil
+	}
+
+	switch e.cfg.Side {
+	case bitx.BID:
+		if md.Ask > e.cfg.PriceLimit {
+			return fmt.Errorf("twap: ask %f moved past price limit %f; aborting", md.Ask, e.cfg.PriceLimit)
+		}
+	case bitx.ASK:
+		if md.Bid < e.cfg.PriceLimit {
+			return fmt.Errorf("twap: bid %f moved past price limit %f; aborting", md.Bid, e.cfg.PriceLimit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"sync"
+
+	"github.com/bitx/bitx-go"
+)
+
+// ActiveOrderBook tracks the set of orders the bot currently has live on
+// the market and emits events as their state changes.
+type ActiveOrderBook struct {
+	mu     sync.Mutex
+	orders map[string]*bitx.Order
+
+	OnNew       func(order *bitx.Order)
+	OnFilled    func(order *bitx.Order)
+	OnCancelled func(order *bitx.Order)
+}
+
+// NewActiveOrderBook constructs an empty ActiveOrderBook.
+func NewActiveOrderBook() *ActiveOrderBook {
+	return &ActiveOrderBook{orders: make(map[string]*bitx.Order)}
+}
+
+// Add registers a freshly placed order and fires OnNew.
+func (b *ActiveOrderBook) Add(order *bitx.Order) {
+	b.mu.Lock()
+	b.orders[order.Id] = order
+	b.mu.Unlock()
+
+	if b.OnNew != nil {
+		b.OnNew(order)
+	}
+}
+
+// Update applies a refreshed order snapshot, firing OnFilled and
+// removing the order once it reaches bitx.Complete. It never fires
+// OnCancelled; cancellations go through Cancel.
+func (b *ActiveOrderBook) Update(order *bitx.Order) {
+	b.mu.Lock()
+	_, tracked := b.orders[order.Id]
+	if tracked {
+		b.orders[order.Id] = order
+	}
+	b.mu.Unlock()
+
+	if !tracked {
+		return
+	}
+
+	switch order.State {
+	case bitx.Complete:
+		if b.OnFilled != nil {
+			b.OnFilled(order)
+		}
+		b.remove(order.Id)
+	default:
+		// Still active; nothing to emit yet.
+	}
+}
+
+// Cancel marks order as cancelled, firing OnCancelled and removing it
+// from the active set. It is a no-op if order is no longer tracked
+// (e.g. Update already resolved it as filled).
+func (b *ActiveOrderBook) Cancel(order *bitx.Order) {
+	b.mu.Lock()
+	_, tracked := b.orders[order.Id]
+	b.mu.Unlock()
+	if !tracked {
+		return
+	}
+
+	b.remove(order.Id)
+	if b.OnCancelled != nil {
+		b.OnCancelled(order)
+	}
+}
+
+// Resolve reconciles an order's final snapshot after a cancel attempt.
+// The underlying API only exposes one terminal state, so an order that
+// reached bitx.Complete may have filled rather than actually been
+// cancelled; Resolve routes to Update (firing OnFilled) in that case
+// and to Cancel (firing OnCancelled) otherwise.
+func (b *ActiveOrderBook) Resolve(order *bitx.Order) {
+	if order.State == bitx.Complete {
+		b.Update(order)
+		return
+	}
+	b.Cancel(order)
+}
+
+func (b *ActiveOrderBook) remove(id string) {
+	b.mu.Lock()
+	delete(b.orders, id)
+	b.mu.Unlock()
+}
+
+// Orders returns a snapshot of the currently active orders.
+func (b *ActiveOrderBook) Orders() []*bitx.Order {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	orders := make([]*bitx.Order, 0, len(b.orders))
+	for _, order := range b.orders {
+		orders = append(orders, order)
+	}
+	return orders
+}
@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/bitx/bitx-go"
+)
+
+func TestActiveOrderBookUpdateFiresOnFilled(t *testing.T) {
+	book := NewActiveOrderBook()
+	var filled *bitx.Order
+	book.OnFilled = func(o *bitx.Order) { filled = o }
+
+	order := &bitx.Order{Id: "1", State: bitx.Pending}
+	book.Add(order)
+
+	updated := &bitx.Order{Id: "1", State: bitx.Complete}
+	book.Update(updated)
+
+	if filled != updated {
+		t.Fatalf("expected OnFilled to fire with the updated order")
+	}
+	if len(book.Orders()) != 0 {
+		t.Fatalf("expected a filled order to be removed from the active set")
+	}
+}
+
+func TestActiveOrderBookUpdateIgnoresUntrackedOrder(t *testing.T) {
+	book := NewActiveOrderBook()
+	fired := false
+	book.OnFilled = func(o *bitx.Order) { fired = true }
+
+	book.Update(&bitx.Order{Id: "unknown", State: bitx.Complete})
+
+	if fired {
+		t.Fatalf("expected Update to ignore an order it never tracked")
+	}
+}
+
+func TestActiveOrderBookCancelFiresOnCancelled(t *testing.T) {
+	book := NewActiveOrderBook()
+	var cancelled *bitx.Order
+	book.OnCancelled = func(o *bitx.Order) { cancelled = o }
+
+	order := &bitx.Order{Id: "1", State: bitx.Pending}
+	book.Add(order)
+	book.Cancel(order)
+
+	if cancelled != order {
+		t.Fatalf("expected OnCancelled to fire with the cancelled order")
+	}
+	if len(book.Orders()) != 0 {
+		t.Fatalf("expected a cancelled order to be removed from the active set")
+	}
+}
+
+func TestActiveOrderBookCancelIsIdempotent(t *testing.T) {
+	book := NewActiveOrderBook()
+	calls := 0
+	book.OnCancelled = func(o *bitx.Order) { calls++ }
+
+	order := &bitx.Order{Id: "1", State: bitx.Pending}
+	book.Add(order)
+	book.Cancel(order)
+	book.Cancel(order) // already untracked; must not fire again
+
+	if calls != 1 {
+		t.Fatalf("expected exactly one OnCancelled, got %d", calls)
+	}
+}
+
+func TestActiveOrderBookResolveRoutesByFinalState(t *testing.T) {
+	book := NewActiveOrderBook()
+	var filled, cancelled *bitx.Order
+	book.OnFilled = func(o *bitx.Order) { filled = o }
+	book.OnCancelled = func(o *bitx.Order) { cancelled = o }
+
+	book.Add(&bitx.Order{Id: "1", State: bitx.Pending})
+	book.Resolve(&bitx.Order{Id: "1", State: bitx.Complete})
+	if filled == nil {
+		t.Fatalf("expected Resolve to route a Complete order to OnFilled")
+	}
+	if cancelled != nil {
+		t.Fatalf("expected Resolve not to fire OnCancelled for a filled order")
+	}
+
+	book.Add(&bitx.Order{Id: "2", State: bitx.Pending})
+	book.Resolve(&bitx.Order{Id: "2", State: bitx.Pending})
+	if cancelled == nil {
+		t.Fatalf("expected Resolve to route a non-Complete order to OnCancelled")
+	}
+}
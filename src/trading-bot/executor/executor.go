@@ -0,0 +1,166 @@
+// Package executor provides a higher-level order-management API on top
+// of the raw bitx-go Client, so the bot can manage several concurrent
+// orders instead of tracking a single lastOrder pointer.
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/client"
+)
+
+// SubmitOrderRequest describes a single order to place.
+type SubmitOrderRequest struct {
+	Pair   string
+	Type   bitx.OrderType
+	Price  float64
+	Volume float64
+}
+
+// OrderExecutor wraps a bitx.Client with batch submission, retry and
+// graceful cancellation semantics.
+type OrderExecutor struct {
+	client client.Client
+
+	// RetryBaseDelay is the initial backoff delay used by
+	// BatchRetryPlaceOrders. Defaults to 500ms.
+	RetryBaseDelay time.Duration
+
+	// CancelPollInterval is how often GracefulCancel polls for
+	// cancellation confirmation. Defaults to 500ms.
+	CancelPollInterval time.Duration
+}
+
+// NewOrderExecutor constructs an OrderExecutor backed by c.
+func NewOrderExecutor(c client.Client) *OrderExecutor {
+	return &OrderExecutor{
+		client:             c,
+		RetryBaseDelay:     500 * time.Millisecond,
+		CancelPollInterval: 500 * time.Millisecond,
+	}
+}
+
+// SubmitOrder places a single order and returns the resulting order
+// details.
+func (e *OrderExecutor) SubmitOrder(ctx context.Context, req SubmitOrderRequest) (*bitx.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	orderId, err := e.client.PostOrder(req.Pair, req.Type, req.Volume, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	return e.client.GetOrder(orderId)
+}
+
+// BatchResult pairs a submitted request with its outcome.
+type BatchResult struct {
+	Request SubmitOrderRequest
+	Order   *bitx.Order
+	Err     error
+}
+
+// BatchPlaceOrders submits every request and returns one BatchResult per
+// request, in order, regardless of individual failures.
+func (e *OrderExecutor) BatchPlaceOrders(ctx context.Context, reqs ...SubmitOrderRequest) []BatchResult {
+	results := make([]BatchResult, len(reqs))
+	for i, req := range reqs {
+		order, err := e.SubmitOrder(ctx, req)
+		results[i] = BatchResult{Request: req, Order: order, Err: err}
+	}
+	return results
+}
+
+// BatchRetryPlaceOrders behaves like BatchPlaceOrders but retries any
+// individually failing request, making at most maxAttempts attempts in
+// all (the first included), backing off exponentially from
+// RetryBaseDelay between attempts.
+func (e *OrderExecutor) BatchRetryPlaceOrders(ctx context.Context, maxAttempts int, reqs ...SubmitOrderRequest) []BatchResult {
+	results := e.BatchPlaceOrders(ctx, reqs...)
+
+	for i, res := range results {
+		if res.Err == nil {
+			continue
+		}
+
+		delay := e.RetryBaseDelay
+		for attempt := 2; attempt <= maxAttempts && res.Err != nil; attempt++ {
+			select {
+			case <-ctx.Done():
+				res.Err = ctx.Err()
+				results[i] = res
+				continue
+			case <-time.After(delay):
+			}
+
+			order, err := e.SubmitOrder(ctx, res.Request)
+			res = BatchResult{Request: res.Request, Order: order, Err: err}
+			delay *= 2
+		}
+		results[i] = res
+	}
+	return results
+}
+
+// GracefulCancel requests cancellation of every order and polls until
+// each reaches bitx.Complete or ctx expires, whichever comes first. It
+// returns the final polled snapshot of each order, in the order given.
+//
+// Reaching bitx.Complete doesn't necessarily mean the order was
+// cancelled: the underlying API only exposes one terminal state, so an
+// order that fills in the window between this call and the cancel
+// reaching the exchange also ends up Complete. Callers that need to
+// distinguish a genuine cancellation from a last-second fill must
+// inspect the returned order rather than assume cancellation succeeded.
+func (e *OrderExecutor) GracefulCancel(ctx context.Context, orders ...*bitx.Order) ([]*bitx.Order, error) {
+	pending := make(map[string]bool, len(orders))
+	final := make(map[string]*bitx.Order, len(orders))
+	for _, order := range orders {
+		// StopOrder failing is expected when the order already
+		// completed (filled or was cancelled) before this call
+		// reached the exchange; either way we still need its final
+		// state, so don't treat this as fatal.
+		e.client.StopOrder(order.Id)
+		pending[order.Id] = true
+		final[order.Id] = order
+	}
+
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return orderedFinal(orders, final), err
+		}
+
+		for id := range pending {
+			updated, err := e.client.GetOrder(id)
+			if err != nil {
+				return orderedFinal(orders, final), err
+			}
+			final[id] = updated
+			if updated.State == bitx.Complete {
+				delete(pending, id)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return orderedFinal(orders, final), ctx.Err()
+		case <-time.After(e.CancelPollInterval):
+		}
+	}
+	return orderedFinal(orders, final), nil
+}
+
+// orderedFinal projects final back into the order orders was given in.
+func orderedFinal(orders []*bitx.Order, final map[string]*bitx.Order) []*bitx.Order {
+	result := make([]*bitx.Order, len(orders))
+	for i, order := range orders {
+		result[i] = final[order.Id]
+	}
+	return result
+}
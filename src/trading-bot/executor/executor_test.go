@@ -0,0 +1,164 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bitx/bitx-go"
+)
+
+// fakeClient is a minimal client.Client stand-in for exercising
+// OrderExecutor without a live exchange.
+type fakeClient struct {
+	orders map[string]*bitx.Order
+	nextID int
+
+	postCalls int
+	failPosts int // leading PostOrder calls to fail before succeeding
+
+	getOrderCalls map[string]int
+	completeAfter int // per-order GetOrder call on which an order flips to bitx.Complete
+
+	stopErr error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		orders:        make(map[string]*bitx.Order),
+		getOrderCalls: make(map[string]int),
+	}
+}
+
+func (f *fakeClient) Balance(asset string) (float64, float64, error) { return 0, 0, nil }
+
+func (f *fakeClient) OrderBook(pair string) ([]bitx.PriceVolume, []bitx.PriceVolume, error) {
+	return nil, nil, nil
+}
+
+func (f *fakeClient) PostOrder(pair string, orderType bitx.OrderType, volume, price float64) (string, error) {
+	f.postCalls++
+	if f.postCalls <= f.failPosts {
+		return "", errors.New("fake: post failed")
+	}
+	f.nextID++
+	id := fmt.Sprintf("fake-%d", f.nextID)
+	f.orders[id] = &bitx.Order{Id: id, Type: orderType, Price: price, Volume: volume, State: bitx.Pending}
+	return id, nil
+}
+
+func (f *fakeClient) GetOrder(id string) (*bitx.Order, error) {
+	order, ok := f.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("fake: unknown order %s", id)
+	}
+	f.getOrderCalls[id]++
+	if f.completeAfter > 0 && f.getOrderCalls[id] >= f.completeAfter {
+		order.State = bitx.Complete
+	}
+	return order, nil
+}
+
+func (f *fakeClient) ListOrders(pair string) ([]bitx.Order, error) { return nil, nil }
+
+func (f *fakeClient) StopOrder(id string) (bool, error) {
+	if f.stopErr != nil {
+		return false, f.stopErr
+	}
+	return true, nil
+}
+
+func TestBatchRetryPlaceOrdersRetriesTransientFailure(t *testing.T) {
+	fc := newFakeClient()
+	fc.failPosts = 1
+
+	exec := NewOrderExecutor(fc)
+	exec.RetryBaseDelay = time.Millisecond
+
+	results := exec.BatchRetryPlaceOrders(context.Background(), 3,
+		SubmitOrderRequest{Pair: "XBTZAR", Type: bitx.BID, Price: 100, Volume: 1})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Err != nil {
+		t.Fatalf("expected the retry to eventually succeed, got error: %s", results[0].Err)
+	}
+	if results[0].Order == nil {
+		t.Fatalf("expected a placed order")
+	}
+}
+
+func TestBatchRetryPlaceOrdersGivesUpAfterMaxAttempts(t *testing.T) {
+	fc := newFakeClient()
+	fc.failPosts = 100 // never succeeds
+
+	exec := NewOrderExecutor(fc)
+	exec.RetryBaseDelay = time.Millisecond
+
+	results := exec.BatchRetryPlaceOrders(context.Background(), 2,
+		SubmitOrderRequest{Pair: "XBTZAR", Type: bitx.BID, Price: 100, Volume: 1})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Err == nil {
+		t.Fatalf("expected an error after exhausting retries")
+	}
+}
+
+func TestGracefulCancelReturnsImmediatelyIfAlreadyComplete(t *testing.T) {
+	fc := newFakeClient()
+	fc.stopErr = errors.New("fake: already settled, cannot stop")
+	order := &bitx.Order{Id: "o1", State: bitx.Complete}
+	fc.orders["o1"] = order
+
+	exec := NewOrderExecutor(fc)
+	final, err := exec.GracefulCancel(context.Background(), order)
+	if err != nil {
+		t.Fatalf("GracefulCancel returned error: %s", err)
+	}
+	if len(final) != 1 || final[0].State != bitx.Complete {
+		t.Fatalf("expected final order to report bitx.Complete, got %+v", final)
+	}
+}
+
+func TestGracefulCancelPollsUntilComplete(t *testing.T) {
+	fc := newFakeClient()
+	fc.completeAfter = 2 // stays Pending for the first poll, Complete on the second
+	order := &bitx.Order{Id: "o1", State: bitx.Pending}
+	fc.orders["o1"] = order
+
+	exec := NewOrderExecutor(fc)
+	exec.CancelPollInterval = time.Millisecond
+
+	final, err := exec.GracefulCancel(context.Background(), order)
+	if err != nil {
+		t.Fatalf("GracefulCancel returned error: %s", err)
+	}
+	if len(final) != 1 || final[0].State != bitx.Complete {
+		t.Fatalf("expected final order to report bitx.Complete once settled, got %+v", final)
+	}
+}
+
+func TestGracefulCancelRespectsContextDeadline(t *testing.T) {
+	fc := newFakeClient()
+	order := &bitx.Order{Id: "o1", State: bitx.Pending}
+	fc.orders["o1"] = order // never flips to Complete
+
+	exec := NewOrderExecutor(fc)
+	exec.CancelPollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	final, err := exec.GracefulCancel(ctx, order)
+	if err == nil {
+		t.Fatalf("expected GracefulCancel to return an error once the context expired")
+	}
+	if len(final) != 1 || final[0].State != bitx.Pending {
+		t.Fatalf("expected the last observed state to still be returned, got %+v", final)
+	}
+}
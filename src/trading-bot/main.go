@@ -2,22 +2,54 @@ package main
 
 import (
 	"bufio"
-	"errors"
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/backtest"
+	"github.com/bitx/trading-bot/client"
+	"github.com/bitx/trading-bot/execution/twap"
+	"github.com/bitx/trading-bot/executor"
+	"github.com/bitx/trading-bot/markets"
+	"github.com/bitx/trading-bot/rebalance"
+	"github.com/bitx/trading-bot/strategy"
+	"github.com/bitx/trading-bot/stream"
 )
 
 var APIKey = flag.String("api_key", "", "API key")
 var APISecret = flag.String("api_secret", "", "API secret")
 var Pair = flag.String("currency_pair", "XBTZAR", "Currency to pair trade")
+var ConfigPath = flag.String("config", "", "Path to strategy config YAML")
+
+var Backtest = flag.Bool("backtest", false, "Replay the strategy against historical data instead of trading live")
+var BacktestData = flag.String("backtest_data", "", "Path to a CSV file of historical bid,ask data")
+var BacktestMakerFee = flag.Float64("backtest_maker_fee", 0, "Maker fee rate for simulated fills (not applied; every fill is charged the taker fee)")
+var BacktestTakerFee = flag.Float64("backtest_taker_fee", 0.001, "Taker fee rate applied to simulated fills")
+var BacktestBaseBalance = flag.Float64("backtest_base_balance", 1, "Starting base-asset balance for the simulation")
+var BacktestQuoteBalance = flag.Float64("backtest_quote_balance", 100000, "Starting quote-asset balance for the simulation")
+
+var Rebalance = flag.Bool("rebalance", false, "Rebalance the portfolio towards target weights instead of market-making")
+var RebalanceTargets = flag.String("rebalance_targets", "", "Path to a rebalance target-weights YAML config")
+var RebalanceMarkets = flag.String("rebalance_markets", "", "Path to a markets-metadata YAML cache")
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "twap" {
+		runTwap(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
+
+	if *Backtest {
+		runBacktest()
+		return
+	}
+
 	fmt.Println("Welcome to the BitX market-making trading bot!")
 
 	if *APIKey == "" || *APISecret == "" {
@@ -25,11 +57,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	c := bitx.NewClient(*APIKey, *APISecret)
-	if c == nil {
-		log.Fatalf("Expected valid BitX client, got: %v", c)
+	bitxClient := bitx.NewClient(*APIKey, *APISecret)
+	if bitxClient == nil {
+		log.Fatalf("Expected valid BitX client, got: %v", bitxClient)
 		os.Exit(1)
 	}
+	var c client.Client = bitxClient
+
+	if *Rebalance {
+		runRebalance(c)
+		return
+	}
 
 	// Check balance
 	bal, res, err := c.Balance(strings.Replace(*Pair, "XBT", "", 1))
@@ -44,12 +82,61 @@ func main() {
 		os.Exit(1)
 	}
 
-	bid, ask, spread, err := getMarketData(c)
+	if *ConfigPath == "" {
+		log.Fatalf("Please supply a strategy config via --config.")
+		os.Exit(1)
+	}
+	cfg, err := strategy.LoadConfig(*ConfigPath)
+	if err != nil {
+		log.Fatalf("Could not load strategy config: %s", err)
+		os.Exit(1)
+	}
+	strat, err := strategy.New(cfg.Strategy)
+	if err != nil {
+		log.Fatalf("Could not create strategy: %s", err)
+		os.Exit(1)
+	}
+	if err := strat.Init(cfg); err != nil {
+		log.Fatalf("Could not initialise strategy %q: %s", cfg.Strategy, err)
+		os.Exit(1)
+	}
+
+	exec := executor.NewOrderExecutor(c)
+	book := executor.NewActiveOrderBook()
+	onOrderTerminal := func(order *bitx.Order) {
+		if err := strat.OnOrderUpdate(order); err != nil {
+			log.Fatalf("Strategy could not process order update: %s", err)
+			os.Exit(1)
+		}
+	}
+	book.OnFilled = onOrderTerminal
+	book.OnCancelled = onOrderTerminal
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bookStream := stream.NewOrderBookStream(c, *Pair, 2*time.Second)
+	go bookStream.Run(ctx)
+
+	userStream := stream.NewUserDataStream(c, *Pair, 2*time.Second)
+	go userStream.Run(ctx)
+	go func() {
+		for event := range userStream.Events() {
+			switch event.Type {
+			case stream.OrderFilled, stream.OrderUpdated:
+				book.Update(event.Order)
+			case stream.OrderCancelled:
+				book.Cancel(event.Order)
+			}
+		}
+	}()
+
+	md, err := waitForMarketData(ctx, bookStream)
 	if err != nil {
 		log.Fatalf("Market not ripe: %s", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Current market\n\tspread: %f\n\tbid: %f\n\task: %f\n", spread, bid, ask)
+	fmt.Printf("Current market\n\tspread: %f\n\tbid: %f\n\task: %f\n", md.Spread, md.Bid, md.Ask)
 
 	doOrder, err := promptYesNo("Place trade?")
 	if err != nil {
@@ -57,101 +144,299 @@ func main() {
 		os.Exit(1)
 	}
 
-	var lastOrder *bitx.Order;
 	for doOrder {
-		lastOrder, err = placeNextOrder(c, lastOrder, bid, ask, spread, 0.0005)
+		ratio, err := inventoryRatio(c, *Pair, (md.Bid+md.Ask)/2)
+		if err != nil {
+			log.Fatalf("Could not fetch balances: %s", err)
+			os.Exit(1)
+		}
+
+		intents, err := strat.OnTick(strategy.MarketData{Bid: md.Bid, Ask: md.Ask, Spread: md.Spread, InventoryRatio: ratio})
 		if err != nil {
-			log.Fatalf("Could not place next order: %s", err)
+			log.Fatalf("Strategy could not decide next move: %s", err)
 			os.Exit(1)
 		}
 
+		reqs := make([]executor.SubmitOrderRequest, len(intents))
+		for i, intent := range intents {
+			reqs[i] = executor.SubmitOrderRequest{Pair: *Pair, Type: intent.Type, Price: intent.Price, Volume: intent.Volume}
+		}
+		for _, res := range exec.BatchRetryPlaceOrders(ctx, 3, reqs...) {
+			if res.Err != nil {
+				log.Fatalf("Could not place order: %s", res.Err)
+				os.Exit(1)
+			}
+			fmt.Printf("Order placed! %+v\n", res.Order)
+			book.Add(res.Order)
+			userStream.Track(res.Order)
+		}
+
 		doOrder, err = promptYesNo("Place another trade if ready?")
 		if err != nil {
 			log.Fatalf("Could not get user confirmation: %s", err)
 			os.Exit(1)
 		}
 
-		bid, ask, spread, err = getMarketData(c)
+		md, err = waitForMarketData(ctx, bookStream)
 		if err != nil {
 			log.Fatalf("Market not ripe: %s", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Current market\n\tspread: %f\n\tbid: %f\n\task: %f\n", spread, bid, ask)
+		fmt.Printf("Current market\n\tspread: %f\n\tbid: %f\n\task: %f\n", md.Spread, md.Bid, md.Ask)
 	}
 
 	fmt.Println("\nBot finished working. Bye.")
 }
 
-func getMarketData(c *bitx.Client) (bid, ask, spread float64, err error) {
-	bids, asks, err := c.OrderBook(*Pair)
+// waitForMarketData blocks until the book stream has produced at least
+// one snapshot, rather than issuing a blocking OrderBook call.
+func waitForMarketData(ctx context.Context, bookStream *stream.OrderBookStream) (stream.BookUpdate, error) {
+	if md, ok := bookStream.Latest(); ok {
+		return md, nil
+	}
+
+	select {
+	case md, open := <-bookStream.Updates():
+		if !open {
+			return stream.BookUpdate{}, fmt.Errorf("book stream closed before producing a snapshot")
+		}
+		return md, nil
+	case <-ctx.Done():
+		return stream.BookUpdate{}, ctx.Err()
+	}
+}
+
+// inventoryRatio fetches the current base/quote balances for pair and
+// returns the base asset's share of total portfolio value, marked at
+// mid, for strategies that skew quotes on inventory drift.
+func inventoryRatio(c client.Client, pair string, mid float64) (float64, error) {
+	quote := strings.Replace(pair, "XBT", "", 1)
+	base := strings.Replace(pair, quote, "", 1)
+
+	baseBal, _, err := c.Balance(base)
+	if err != nil {
+		return 0, err
+	}
+	quoteBal, _, err := c.Balance(quote)
 	if err != nil {
-		return 0, 0, 0, err
+		return 0, err
 	}
 
-	if len(bids) == 0 || len(asks) == 0 {
-		return 0, 0, 0, errors.New("Not enough liquidity on market")
+	baseValue := baseBal * mid
+	total := baseValue + quoteBal
+	if total == 0 {
+		return 0, nil
 	}
-	bid = bids[0].Price
-	ask = asks[0].Price
-	return bid, ask, ask - bid, nil
+	return baseValue / total, nil
 }
 
-func promptYesNo(question string) (yes bool, err error) {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s [Y/n] ", question)
-	text, _ := reader.ReadString('\n')
+// runBacktest replays the configured strategy against historical data
+// loaded from --backtest_data and prints a run summary, rather than
+// trading live.
+func runBacktest() {
+	fmt.Println("Running BitX trading bot in backtest mode.")
 
-	firstChr := strings.ToLower(text)[0]
-	if text == "" || firstChr == 'y' || firstChr == 10 {
-		return true, nil
+	if *BacktestData == "" {
+		log.Fatalf("Please supply historical data via --backtest_data.")
+		os.Exit(1)
 	}
-	return false, nil
+	if *ConfigPath == "" {
+		log.Fatalf("Please supply a strategy config via --config.")
+		os.Exit(1)
+	}
+
+	ticks, err := backtest.LoadTicks(*BacktestData)
+	if err != nil {
+		log.Fatalf("Could not load historical data: %s", err)
+		os.Exit(1)
+	}
+
+	cfg, err := strategy.LoadConfig(*ConfigPath)
+	if err != nil {
+		log.Fatalf("Could not load strategy config: %s", err)
+		os.Exit(1)
+	}
+	strat, err := strategy.New(cfg.Strategy)
+	if err != nil {
+		log.Fatalf("Could not create strategy: %s", err)
+		os.Exit(1)
+	}
+	if err := strat.Init(cfg); err != nil {
+		log.Fatalf("Could not initialise strategy %q: %s", cfg.Strategy, err)
+		os.Exit(1)
+	}
+
+	quote := strings.Replace(*Pair, "XBT", "", 1)
+	base := strings.Replace(*Pair, quote, "", 1)
+	startingBalances := map[string]float64{
+		base:  *BacktestBaseBalance,
+		quote: *BacktestQuoteBalance,
+	}
+	fees := backtest.Fees{MakerRate: *BacktestMakerFee, TakerRate: *BacktestTakerFee}
+	sim := backtest.NewSimulator(*Pair, ticks, fees, startingBalances)
+
+	if err := backtest.Run(strat, sim); err != nil {
+		log.Fatalf("Backtest failed: %s", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nBacktest finished. Run summary:")
+	fmt.Println(sim.Summarize(*Pair, startingBalances))
 }
 
-func placeNextOrder(c *bitx.Client, lastOrder *bitx.Order, bid, ask, spread, volume float64) (order *bitx.Order, err error) {
-	// Fetch or refresh order
-	if lastOrder == nil {
-		fmt.Println("Fetching NEW last order...")
-		orders, err := c.ListOrders(*Pair)
+// runRebalance fetches balances and market data for every target asset,
+// computes the minimum set of orders needed to bring the portfolio
+// within the configured threshold of target, and submits them (unless
+// cfg.DryRun is set).
+func runRebalance(c client.Client) {
+	fmt.Println("Running BitX trading bot in rebalance mode.")
+
+	if *RebalanceTargets == "" {
+		log.Fatalf("Please supply target weights via --rebalance_targets.")
+		os.Exit(1)
+	}
+
+	cfg, err := rebalance.LoadConfig(*RebalanceTargets)
+	if err != nil {
+		log.Fatalf("Could not load rebalance config: %s", err)
+		os.Exit(1)
+	}
+
+	cache := markets.NewCache()
+	if *RebalanceMarkets != "" {
+		cache, err = markets.LoadCache(*RebalanceMarkets)
+		if err != nil {
+			log.Fatalf("Could not load markets metadata: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	balances := make(map[string]float64, len(cfg.Targets))
+	midPrices := make(map[string]float64, len(cfg.Targets))
+	for asset := range cfg.Targets {
+		bal, _, err := c.Balance(asset)
 		if err != nil {
-			return lastOrder, err
+			log.Fatalf("Could not fetch balance for %s: %s", asset, err)
+			os.Exit(1)
 		}
-		if len(orders) > 0 {
-			// First order in this run
-			lastOrder = &orders[0]
+		balances[asset] = bal
+
+		if asset == cfg.Quote {
+			continue
 		}
-	} else {
-		// Refresh order
-		fmt.Printf("Refreshing last order (%s)...\n", lastOrder.Id)
-		lastOrder, err = c.GetOrder(lastOrder.Id)
+		bids, asks, err := c.OrderBook(asset + cfg.Quote)
 		if err != nil {
-			return lastOrder, err
+			log.Fatalf("Could not fetch order book for %s%s: %s", asset, cfg.Quote, err)
+			os.Exit(1)
+		}
+		if len(bids) == 0 || len(asks) == 0 {
+			log.Fatalf("Not enough liquidity to price %s%s", asset, cfg.Quote)
+			os.Exit(1)
+		}
+		midPrices[asset] = (bids[0].Price + asks[0].Price) / 2
+	}
+
+	plans, err := rebalance.Plan(cfg, balances, midPrices, cache)
+	if err != nil {
+		log.Fatalf("Could not compute rebalance plan: %s", err)
+		os.Exit(1)
+	}
+	if len(plans) == 0 {
+		fmt.Println("Portfolio already within threshold of target weights. Nothing to do.")
+		return
+	}
+
+	for _, plan := range plans {
+		fmt.Printf("Plan: %s %s %f @ %f\n", plan.Type, plan.Pair, plan.Volume, plan.Price)
+	}
+	if cfg.DryRun {
+		fmt.Println("Dry run: no orders submitted.")
+		return
+	}
+
+	exec := executor.NewOrderExecutor(c)
+	reqs := make([]executor.SubmitOrderRequest, len(plans))
+	for i, plan := range plans {
+		reqs[i] = executor.SubmitOrderRequest{Pair: plan.Pair, Type: plan.Type, Price: plan.Price, Volume: plan.Volume}
+	}
+	for _, res := range exec.BatchRetryPlaceOrders(context.Background(), 3, reqs...) {
+		if res.Err != nil {
+			log.Fatalf("Could not place rebalancing order: %s", res.Err)
+			os.Exit(1)
 		}
+		fmt.Printf("Order placed! %+v\n", res.Order)
 	}
+}
+
+// runTwap implements the "twap" subcommand: bot twap --pair XBTZAR
+// --side buy --quantity 0.1 --duration 30m.
+func runTwap(args []string) {
+	fs := flag.NewFlagSet("twap", flag.ExitOnError)
+	apiKey := fs.String("api_key", "", "API key")
+	apiSecret := fs.String("api_secret", "", "API secret")
+	pair := fs.String("pair", "XBTZAR", "Currency pair to trade")
+	side := fs.String("side", "buy", "Side to execute: buy or sell")
+	quantity := fs.Float64("quantity", 0, "Total quantity to execute")
+	duration := fs.Duration("duration", 30*time.Minute, "Total duration of the execution")
+	sliceInterval := fs.Duration("slice_interval", time.Minute, "How often to re-slice and repost the remainder")
+	numberOfTicks := fs.Float64("number_of_ticks", 1, "How many ticks inside the spread to post child orders")
+	priceLimit := fs.Float64("price_limit", 0, "Abort if the market crosses this price; 0 disables the guard")
+	fs.Parse(args)
 
-	// Check if last order has executed
-	fmt.Printf("Last order: %+v\n", lastOrder)
-	if lastOrder.State != bitx.Complete {
-		fmt.Println("Order has not completed yet.")
-		return lastOrder, nil
+	if *apiKey == "" || *apiSecret == "" {
+		log.Fatalf("Please supply API key and secret via --api_key/--api_secret.")
+		os.Exit(1)
+	}
+	if *quantity <= 0 {
+		log.Fatalf("Please supply a positive --quantity.")
+		os.Exit(1)
 	}
 
-	// Time to place a new one
-	orderType := bitx.BID
-	price := bid + 1;
-	if lastOrder != nil && lastOrder.Type == bitx.BID {
+	var orderType bitx.OrderType
+	switch strings.ToLower(*side) {
+	case "buy":
+		orderType = bitx.BID
+	case "sell":
 		orderType = bitx.ASK
-		price = ask - 1;
+	default:
+		log.Fatalf("Unknown --side %q; expected buy or sell.", *side)
+		os.Exit(1)
+	}
+
+	bitxClient := bitx.NewClient(*apiKey, *apiSecret)
+	if bitxClient == nil {
+		log.Fatalf("Expected valid BitX client, got: %v", bitxClient)
+		os.Exit(1)
+	}
+	var c client.Client = bitxClient
+
+	fmt.Printf("Running TWAP execution: %s %f %s over %s\n", *side, *quantity, *pair, *duration)
+
+	execution := twap.NewExecution(twap.Config{
+		Pair:          *pair,
+		Side:          orderType,
+		Quantity:      *quantity,
+		Duration:      *duration,
+		SliceInterval: *sliceInterval,
+		NumberOfTicks: *numberOfTicks,
+		PriceLimit:    *priceLimit,
+	}, c)
+
+	if err := execution.Run(context.Background()); err != nil {
+		log.Fatalf("TWAP execution failed: %s", err)
+		os.Exit(1)
 	}
-	return placeOrder(c, orderType, price, volume)
+	fmt.Printf("TWAP execution finished. Filled %f of %f.\n", execution.Filled(), *quantity)
 }
 
-func placeOrder(c *bitx.Client, orderType bitx.OrderType, price, volume float64) (*bitx.Order, error) {
-	fmt.Printf("Placing order of type: %s, price: %f, volume: %f\n", orderType, price, volume)
-	orderId, err := c.PostOrder(*Pair, orderType, volume, price)
-	if err != nil {
-		return nil, err
+func promptYesNo(question string) (yes bool, err error) {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("%s [Y/n] ", question)
+	text, _ := reader.ReadString('\n')
+
+	firstChr := strings.ToLower(text)[0]
+	if text == "" || firstChr == 'y' || firstChr == 10 {
+		return true, nil
 	}
-	fmt.Printf("Order placed! Fetching order details: %s\n", orderId)
-	return c.GetOrder(orderId)
+	return false, nil
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+// Package markets caches per-market trading metadata (minimum order
+// size, size increments, minimum notional) so other packages can reject
+// orders the exchange would refuse before submitting them.
+package markets
+
+import (
+	"io/ioutil"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Meta describes a single market's trading constraints.
+type Meta struct {
+	Pair        string  `yaml:"pair"`
+	MinQuantity float64 `yaml:"min_quantity"`
+	StepSize    float64 `yaml:"step_size"`
+	MinNotional float64 `yaml:"min_notional"`
+}
+
+// Cache holds Meta by pair, safe for concurrent use.
+type Cache struct {
+	mu   sync.RWMutex
+	meta map[string]Meta
+}
+
+// NewCache constructs an empty Cache.
+func NewCache() *Cache {
+	return &Cache{meta: make(map[string]Meta)}
+}
+
+// Set stores m, keyed by m.Pair.
+func (c *Cache) Set(m Meta) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.meta[m.Pair] = m
+}
+
+// Get returns the Meta for pair, if known.
+func (c *Cache) Get(pair string) (Meta, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.meta[pair]
+	return m, ok
+}
+
+// LoadCache reads a YAML file listing market metadata and returns a
+// populated Cache.
+func LoadCache(path string) (*Cache, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var metas []Meta
+	if err := yaml.Unmarshal(data, &metas); err != nil {
+		return nil, err
+	}
+
+	cache := NewCache()
+	for _, m := range metas {
+		cache.Set(m)
+	}
+	return cache, nil
+}
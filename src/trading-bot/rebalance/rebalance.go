@@ -0,0 +1,100 @@
+// Package rebalance extends the bot's single-pair order placement to a
+// portfolio-level control loop: given target weights per asset, it
+// computes the minimum set of orders needed to bring the portfolio back
+// within a threshold of target.
+package rebalance
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/markets"
+)
+
+// Config describes a rebalance run.
+type Config struct {
+	// Quote is the settlement asset all target weights and prices are
+	// expressed against, e.g. "ZAR".
+	Quote string `yaml:"quote"`
+
+	// Targets maps asset (including Quote) to its target fraction of
+	// total portfolio value. Weights should sum to 1.
+	Targets map[string]float64 `yaml:"targets"`
+
+	// Threshold is the fraction of total portfolio value a single
+	// asset's weight must drift by before a rebalancing trade fires.
+	Threshold float64 `yaml:"threshold"`
+
+	// DryRun, when true, computes the plan without submitting orders.
+	DryRun bool `yaml:"dry_run"`
+}
+
+// OrderPlan is a single order needed to move the portfolio towards
+// target weights.
+type OrderPlan struct {
+	Pair   string
+	Type   bitx.OrderType
+	Volume float64
+	Price  float64
+}
+
+// Plan computes the minimum set of orders needed to bring every asset's
+// weight within cfg.Threshold of its target, given current balances and
+// mid prices (asset -> price in cfg.Quote). Orders that would fall
+// under a market's MinQuantity, StepSize, or MinNotional are dropped.
+func Plan(cfg Config, balances, midPrices map[string]float64, cache *markets.Cache) ([]OrderPlan, error) {
+	totalValue := 0.0
+	for asset, bal := range balances {
+		if asset == cfg.Quote {
+			totalValue += bal
+			continue
+		}
+		price, ok := midPrices[asset]
+		if !ok {
+			return nil, fmt.Errorf("rebalance: no market data for %s", asset)
+		}
+		totalValue += bal * price
+	}
+
+	var plans []OrderPlan
+	for asset, target := range cfg.Targets {
+		if asset == cfg.Quote {
+			continue
+		}
+
+		price, ok := midPrices[asset]
+		if !ok {
+			return nil, fmt.Errorf("rebalance: no market data for %s", asset)
+		}
+
+		currentValue := balances[asset] * price
+		targetValue := totalValue * target
+		diff := targetValue - currentValue
+		if totalValue == 0 || math.Abs(diff)/totalValue < cfg.Threshold {
+			continue
+		}
+
+		pair := asset + cfg.Quote
+		orderType := bitx.BID
+		if diff < 0 {
+			orderType = bitx.ASK
+		}
+		volume := math.Abs(diff) / price
+
+		if meta, ok := cache.Get(pair); ok {
+			if meta.StepSize > 0 {
+				volume = math.Floor(volume/meta.StepSize) * meta.StepSize
+			}
+			if volume < meta.MinQuantity || volume*price < meta.MinNotional {
+				continue
+			}
+		}
+		if volume <= 0 {
+			continue
+		}
+
+		plans = append(plans, OrderPlan{Pair: pair, Type: orderType, Volume: volume, Price: price})
+	}
+	return plans, nil
+}
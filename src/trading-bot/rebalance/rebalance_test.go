@@ -0,0 +1,73 @@
+package rebalance
+
+import (
+	"testing"
+
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/markets"
+)
+
+func TestPlanSkipsWithinThreshold(t *testing.T) {
+	cfg := Config{
+		Quote:     "ZAR",
+		Targets:   map[string]float64{"XBT": 0.5, "ZAR": 0.5},
+		Threshold: 0.05,
+	}
+	balances := map[string]float64{"XBT": 0.5, "ZAR": 50000}
+	midPrices := map[string]float64{"XBT": 100000}
+
+	plans, err := Plan(cfg, balances, midPrices, markets.NewCache())
+	if err != nil {
+		t.Fatalf("Plan returned error: %s", err)
+	}
+	if len(plans) != 0 {
+		t.Fatalf("expected no plans for a portfolio already at target, got %+v", plans)
+	}
+}
+
+func TestPlanSellsOverweightAsset(t *testing.T) {
+	cfg := Config{
+		Quote:     "ZAR",
+		Targets:   map[string]float64{"XBT": 0.5, "ZAR": 0.5},
+		Threshold: 0.02,
+	}
+	// XBT is worth 80000 of the 100000 portfolio (80%), well over its
+	// 50% target, so Plan should propose selling XBT for ZAR.
+	balances := map[string]float64{"XBT": 0.8, "ZAR": 20000}
+	midPrices := map[string]float64{"XBT": 100000}
+
+	plans, err := Plan(cfg, balances, midPrices, markets.NewCache())
+	if err != nil {
+		t.Fatalf("Plan returned error: %s", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("expected exactly one plan, got %+v", plans)
+	}
+	if plans[0].Type != bitx.ASK {
+		t.Errorf("expected an ASK order to reduce XBT overweight, got %v", plans[0].Type)
+	}
+	if plans[0].Pair != "XBTZAR" {
+		t.Errorf("expected pair XBTZAR, got %s", plans[0].Pair)
+	}
+}
+
+func TestPlanDropsOrdersBelowMarketMinimums(t *testing.T) {
+	cfg := Config{
+		Quote:     "ZAR",
+		Targets:   map[string]float64{"XBT": 0.5, "ZAR": 0.5},
+		Threshold: 0.02,
+	}
+	balances := map[string]float64{"XBT": 0.55, "ZAR": 45000}
+	midPrices := map[string]float64{"XBT": 100000}
+
+	cache := markets.NewCache()
+	cache.Set(markets.Meta{Pair: "XBTZAR", MinNotional: 10000})
+
+	plans, err := Plan(cfg, balances, midPrices, cache)
+	if err != nil {
+		t.Fatalf("Plan returned error: %s", err)
+	}
+	if len(plans) != 0 {
+		t.Fatalf("expected the order to be dropped for falling under MinNotional, got %+v", plans)
+	}
+}
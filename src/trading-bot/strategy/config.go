@@ -0,0 +1,30 @@
+package strategy
+
+import (
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config describes which strategy to run and holds the parameters for
+// every built-in strategy. Only the section matching Strategy is used.
+type Config struct {
+	Strategy string                `yaml:"strategy"`
+	PingPong PingPongConfig        `yaml:"ping_pong"`
+	Layered  LayeredConfig         `yaml:"layered"`
+	Skewed   InventorySkewedConfig `yaml:"inventory_skewed"`
+}
+
+// LoadConfig reads and parses the strategy configuration file passed via
+// --config.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,87 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/bitx/bitx-go"
+)
+
+// LayeredConfig configures the layered liquidity-provider strategy.
+type LayeredConfig struct {
+	Levels      int     `yaml:"levels"`       // number of orders placed on each side
+	RangeTicks  float64 `yaml:"range_ticks"`  // price distance spanned by the outermost level
+	BaseVolume  float64 `yaml:"base_volume"`  // volume of the innermost (best-priced) level
+	ScaleFactor float64 `yaml:"scale_factor"` // k: outermost level's volume is BaseVolume*k
+}
+
+// Layered places Levels orders on each side of the book, spread evenly
+// across RangeTicks, with per-level volume scaling exponentially from
+// BaseVolume at the top of book to BaseVolume*ScaleFactor at the far
+// edge of the range. Like PingPong, it waits for every order from the
+// previous Decide to complete before posting a fresh set, rather than
+// stacking a new layer of orders on top of ones still resting.
+type Layered struct {
+	cfg LayeredConfig
+
+	resting int
+}
+
+func (l *Layered) Init(cfg Config) error {
+	l.cfg = cfg.Layered
+	if l.cfg.Levels <= 0 {
+		l.cfg.Levels = 1
+	}
+	if l.cfg.ScaleFactor <= 0 {
+		l.cfg.ScaleFactor = 1
+	}
+	return nil
+}
+
+// OnOrderUpdate is only called by the bot for an order's terminal
+// transition (filled or cancelled), so either one counts against
+// resting.
+func (l *Layered) OnOrderUpdate(order *bitx.Order) error {
+	if l.resting > 0 {
+		l.resting--
+	}
+	return nil
+}
+
+func (l *Layered) OnTick(md MarketData) ([]OrderIntent, error) {
+	return l.Decide(md)
+}
+
+// scale maps level n in [1..Levels] onto [1..ScaleFactor] exponentially,
+// so the innermost level gets BaseVolume and the outermost gets
+// BaseVolume*ScaleFactor.
+func (l *Layered) scale(n int) float64 {
+	if l.cfg.Levels == 1 {
+		return l.cfg.BaseVolume
+	}
+	t := float64(n-1) / float64(l.cfg.Levels-1)
+	return l.cfg.BaseVolume * math.Pow(l.cfg.ScaleFactor, t)
+}
+
+func (l *Layered) Decide(md MarketData) ([]OrderIntent, error) {
+	if l.resting > 0 {
+		return nil, nil
+	}
+
+	intents := make([]OrderIntent, 0, l.cfg.Levels*2)
+	step := 0.0
+	if l.cfg.Levels > 1 {
+		step = l.cfg.RangeTicks / float64(l.cfg.Levels-1)
+	}
+
+	for n := 1; n <= l.cfg.Levels; n++ {
+		volume := l.scale(n)
+		offset := step * float64(n-1)
+		intents = append(intents,
+			OrderIntent{Type: bitx.BID, Price: md.Bid - offset, Volume: volume},
+			OrderIntent{Type: bitx.ASK, Price: md.Ask + offset, Volume: volume},
+		)
+	}
+
+	l.resting = len(intents)
+	return intents, nil
+}
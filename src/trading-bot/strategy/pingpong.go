@@ -0,0 +1,54 @@
+package strategy
+
+import "github.com/bitx/bitx-go"
+
+// PingPongConfig holds the parameters for the PingPong strategy.
+type PingPongConfig struct {
+	Volume float64 `yaml:"volume"`
+}
+
+// PingPong alternates a single BID and ASK order one tick inside the
+// current spread, waiting for the previous order to complete before
+// placing the next one. This mirrors the bot's original behavior.
+type PingPong struct {
+	cfg      PingPongConfig
+	lastSide bitx.OrderType
+	hasOrder bool
+}
+
+func (p *PingPong) Init(cfg Config) error {
+	p.cfg = cfg.PingPong
+	if p.cfg.Volume == 0 {
+		p.cfg.Volume = 0.0005
+	}
+	return nil
+}
+
+// OnOrderUpdate is only called by the bot for an order's terminal
+// transition (filled or cancelled), so either one clears hasOrder and
+// lets Decide quote again.
+func (p *PingPong) OnOrderUpdate(order *bitx.Order) error {
+	p.hasOrder = false
+	return nil
+}
+
+func (p *PingPong) OnTick(md MarketData) ([]OrderIntent, error) {
+	return p.Decide(md)
+}
+
+func (p *PingPong) Decide(md MarketData) ([]OrderIntent, error) {
+	if p.hasOrder {
+		return nil, nil
+	}
+
+	orderType := bitx.BID
+	price := md.Bid + 1
+	if p.lastSide == bitx.BID {
+		orderType = bitx.ASK
+		price = md.Ask - 1
+	}
+
+	p.lastSide = orderType
+	p.hasOrder = true
+	return []OrderIntent{{Type: orderType, Price: price, Volume: p.cfg.Volume}}, nil
+}
@@ -0,0 +1,57 @@
+package strategy
+
+import "github.com/bitx/bitx-go"
+
+// InventorySkewedConfig configures the inventory-skewed quoter.
+type InventorySkewedConfig struct {
+	Volume      float64 `yaml:"volume"`
+	TargetRatio float64 `yaml:"target_ratio"`  // target XBT share of total XBT+ZAR value, e.g. 0.5
+	SkewPerUnit float64 `yaml:"skew_per_unit"` // price shift applied per unit of ratio deviation
+}
+
+// InventorySkewed quotes around a mid price shifted by how far the
+// current XBT share of portfolio value (MarketData.InventoryRatio) has drifted
+// from TargetRatio, so fills naturally walk inventory back towards
+// target. Like PingPong, it waits for both quotes from the previous
+// Decide to complete before posting a fresh pair.
+type InventorySkewed struct {
+	cfg InventorySkewedConfig
+
+	resting int
+}
+
+func (s *InventorySkewed) Init(cfg Config) error {
+	s.cfg = cfg.Skewed
+	return nil
+}
+
+// OnOrderUpdate is only called by the bot for an order's terminal
+// transition (filled or cancelled), so either one counts against
+// resting.
+func (s *InventorySkewed) OnOrderUpdate(order *bitx.Order) error {
+	if s.resting > 0 {
+		s.resting--
+	}
+	return nil
+}
+
+func (s *InventorySkewed) OnTick(md MarketData) ([]OrderIntent, error) {
+	return s.Decide(md)
+}
+
+func (s *InventorySkewed) Decide(md MarketData) ([]OrderIntent, error) {
+	if s.resting > 0 {
+		return nil, nil
+	}
+
+	mid := (md.Bid + md.Ask) / 2
+	skew := (md.InventoryRatio - s.cfg.TargetRatio) * s.cfg.SkewPerUnit
+	skewedMid := mid - skew
+
+	intents := []OrderIntent{
+		{Type: bitx.BID, Price: skewedMid - md.Spread/2, Volume: s.cfg.Volume},
+		{Type: bitx.ASK, Price: skewedMid + md.Spread/2, Volume: s.cfg.Volume},
+	}
+	s.resting = len(intents)
+	return intents, nil
+}
@@ -0,0 +1,65 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/bitx/bitx-go"
+)
+
+// MarketData is the snapshot of top-of-book and account state passed to
+// a Strategy on every tick.
+type MarketData struct {
+	Bid, Ask, Spread float64
+
+	// InventoryRatio is the current base-asset value as a fraction of
+	// total portfolio value (base + quote, both marked at Bid/Ask mid),
+	// refreshed by the caller from live or simulated balances before
+	// each tick. Strategies that don't skew on inventory can ignore it.
+	InventoryRatio float64
+}
+
+// OrderIntent is an order a Strategy wants placed. The bot is
+// responsible for actually submitting it and feeding the resulting
+// order back via OnOrderUpdate.
+type OrderIntent struct {
+	Type   bitx.OrderType
+	Price  float64
+	Volume float64
+}
+
+// Strategy is the pluggable decision-making core of the bot. The bot
+// drives a Strategy with fresh market data and order updates; built-in
+// implementations live alongside this file.
+type Strategy interface {
+	// Init is called once with the strategy's configuration before the
+	// bot starts trading.
+	Init(cfg Config) error
+
+	// OnTick is called whenever fresh market data is available and
+	// returns the orders, if any, the strategy wants placed.
+	OnTick(md MarketData) ([]OrderIntent, error)
+
+	// OnOrderUpdate is called when one of the strategy's orders reaches
+	// a terminal state (filled or cancelled).
+	OnOrderUpdate(order *bitx.Order) error
+
+	// Decide is the strategy's core decision function. OnTick calls it
+	// after refreshing any internal state; it's exposed separately so
+	// strategies can be exercised without going through OnTick.
+	Decide(md MarketData) ([]OrderIntent, error)
+}
+
+// New constructs the built-in Strategy named by name (typically
+// cfg.Strategy from a loaded Config).
+func New(name string) (Strategy, error) {
+	switch name {
+	case "ping_pong":
+		return &PingPong{}, nil
+	case "layered":
+		return &Layered{}, nil
+	case "inventory_skewed":
+		return &InventorySkewed{}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy: %s", name)
+	}
+}
@@ -0,0 +1,30 @@
+package stream
+
+import "time"
+
+// backoff computes successive exponential delays, capped at max, reset
+// to zero after a successful attempt.
+type backoff struct {
+	base, max time.Duration
+	attempt   uint
+}
+
+func newBackoff(base, max time.Duration) *backoff {
+	return &backoff{base: base, max: max}
+}
+
+// Next returns the delay to wait before the next retry and advances the
+// attempt counter.
+func (b *backoff) Next() time.Duration {
+	d := b.base << b.attempt
+	if d <= 0 || d > b.max {
+		d = b.max
+	}
+	b.attempt++
+	return d
+}
+
+// Reset clears the attempt counter after a successful call.
+func (b *backoff) Reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,29 @@
+package stream
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDoublesUntilCapped(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second)
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := b.Next(); got != w {
+			t.Fatalf("Next() call %d: got %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestBackoffResetClearsAttemptCounter(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second)
+
+	b.Next()
+	b.Next()
+	b.Reset()
+
+	if got, want := b.Next(), time.Second; got != want {
+		t.Fatalf("Next() after Reset: got %s, want %s", got, want)
+	}
+}
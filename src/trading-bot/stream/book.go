@@ -0,0 +1,116 @@
+// Package stream maintains locally-mirrored market and order-book state
+// by refreshing it in the background, so the bot can react to events
+// instead of blocking on a request/response call between every prompt.
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bitx/trading-bot/client"
+)
+
+// BookUpdate is a refreshed top-of-book snapshot.
+type BookUpdate struct {
+	Bid, Ask, Spread float64
+}
+
+// OrderBookStream keeps a locally-mirrored copy of the top of the order
+// book for a pair, refreshed on a timer with exponential backoff on
+// failure. Consumers can either read the latest snapshot directly or
+// subscribe to the Updates channel.
+type OrderBookStream struct {
+	client   client.Client
+	pair     string
+	interval time.Duration
+
+	updates chan BookUpdate
+
+	mu     sync.RWMutex
+	latest BookUpdate
+	ok     bool
+}
+
+// NewOrderBookStream constructs a stream that refreshes pair's order
+// book every interval.
+func NewOrderBookStream(c client.Client, pair string, interval time.Duration) *OrderBookStream {
+	return &OrderBookStream{
+		client:   c,
+		pair:     pair,
+		interval: interval,
+		updates:  make(chan BookUpdate, 16),
+	}
+}
+
+// Updates returns the channel of book snapshots. It is closed when Run
+// returns.
+func (s *OrderBookStream) Updates() <-chan BookUpdate {
+	return s.updates
+}
+
+// Latest returns the most recently observed snapshot. ok is false until
+// the first successful refresh.
+func (s *OrderBookStream) Latest() (update BookUpdate, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest, s.ok
+}
+
+// Run refreshes the book until ctx is cancelled, reconnecting with
+// exponential backoff after errors.
+func (s *OrderBookStream) Run(ctx context.Context) error {
+	defer close(s.updates)
+
+	b := newBackoff(time.Second, 30*time.Second)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		update, err := s.refresh()
+		if err != nil {
+			if !s.wait(ctx, b.Next()) {
+				return ctx.Err()
+			}
+			continue
+		}
+		b.Reset()
+
+		s.mu.Lock()
+		s.latest, s.ok = update, true
+		s.mu.Unlock()
+
+		// Backpressure-aware: drop the update rather than block if no
+		// one is listening.
+		select {
+		case s.updates <- update:
+		default:
+		}
+
+		if !s.wait(ctx, s.interval) {
+			return ctx.Err()
+		}
+		ticker.Reset(s.interval)
+	}
+}
+
+func (s *OrderBookStream) refresh() (BookUpdate, error) {
+	bids, asks, err := s.client.OrderBook(s.pair)
+	if err != nil {
+		return BookUpdate{}, err
+	}
+	if len(bids) == 0 || len(asks) == 0 {
+		return BookUpdate{}, errNotEnoughLiquidity
+	}
+	bid, ask := bids[0].Price, asks[0].Price
+	return BookUpdate{Bid: bid, Ask: ask, Spread: ask - bid}, nil
+}
+
+func (s *OrderBookStream) wait(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
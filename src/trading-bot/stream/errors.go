@@ -0,0 +1,5 @@
+package stream
+
+import "errors"
+
+var errNotEnoughLiquidity = errors.New("not enough liquidity on market")
@@ -0,0 +1,195 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bitx/bitx-go"
+	"github.com/bitx/trading-bot/client"
+)
+
+// OrderEventType classifies an OrderEvent.
+type OrderEventType int
+
+const (
+	// OrderNew is emitted the first time an order is tracked.
+	OrderNew OrderEventType = iota
+	// OrderUpdated is emitted when a tracked order's state changes
+	// without completing or being cancelled.
+	OrderUpdated
+	// OrderFilled is emitted once a tracked order reaches bitx.Complete.
+	OrderFilled
+	// OrderCancelled is emitted once a tracked order is no longer
+	// reported by ListOrders and didn't complete first.
+	OrderCancelled
+)
+
+// OrderEvent describes a state transition observed for a tracked order.
+type OrderEvent struct {
+	Type  OrderEventType
+	Order *bitx.Order
+}
+
+// UserDataStream polls the state of tracked orders on an interval and
+// emits events as they transition, so the bot can react instead of
+// blocking on GetOrder between prompts.
+type UserDataStream struct {
+	client   client.Client
+	pair     string
+	interval time.Duration
+
+	events chan OrderEvent
+
+	mu      sync.Mutex
+	tracked map[string]bitx.OrderState
+}
+
+// NewUserDataStream constructs a stream that polls every interval for
+// state changes on tracked orders in pair.
+func NewUserDataStream(c client.Client, pair string, interval time.Duration) *UserDataStream {
+	return &UserDataStream{
+		client:   c,
+		pair:     pair,
+		interval: interval,
+		events:   make(chan OrderEvent, 16),
+		tracked:  make(map[string]bitx.OrderState),
+	}
+}
+
+// Events returns the channel of order state transitions. It is closed
+// when Run returns.
+func (s *UserDataStream) Events() <-chan OrderEvent {
+	return s.events
+}
+
+// Track adds order to the set being watched and immediately emits
+// OrderNew.
+func (s *UserDataStream) Track(order *bitx.Order) {
+	s.mu.Lock()
+	s.tracked[order.Id] = order.State
+	s.mu.Unlock()
+
+	s.emit(OrderEvent{Type: OrderNew, Order: order})
+}
+
+// Run polls tracked orders until ctx is cancelled, reconnecting with
+// exponential backoff after errors.
+func (s *UserDataStream) Run(ctx context.Context) error {
+	defer close(s.events)
+
+	b := newBackoff(time.Second, 30*time.Second)
+
+	for {
+		if err := s.poll(); err != nil {
+			if !s.wait(ctx, b.Next()) {
+				return ctx.Err()
+			}
+			continue
+		}
+		b.Reset()
+
+		if !s.wait(ctx, s.interval) {
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *UserDataStream) poll() error {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.tracked))
+	for id := range s.tracked {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	for _, id := range ids {
+		order, err := s.client.GetOrder(id)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		prev, ok := s.tracked[id]
+		if ok {
+			s.tracked[id] = order.State
+		}
+		s.mu.Unlock()
+
+		if !ok || prev == order.State {
+			continue
+		}
+
+		event := OrderEvent{Type: OrderUpdated, Order: order}
+		if order.State == bitx.Complete {
+			event.Type = OrderFilled
+			s.untrack(id)
+		}
+		s.emit(event)
+	}
+
+	return s.pollCancellations()
+}
+
+// pollCancellations diffs the still-tracked ids against ListOrders,
+// which only reports orders still open on the exchange. A tracked order
+// absent from that list didn't complete (poll's GetOrder pass above
+// would have already caught and untracked a fill), so it must have been
+// cancelled out from under us.
+func (s *UserDataStream) pollCancellations() error {
+	s.mu.Lock()
+	if len(s.tracked) == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	remaining := make(map[string]bool, len(s.tracked))
+	for id := range s.tracked {
+		remaining[id] = true
+	}
+	s.mu.Unlock()
+
+	open, err := s.client.ListOrders(s.pair)
+	if err != nil {
+		return err
+	}
+	for _, order := range open {
+		delete(remaining, order.Id)
+	}
+
+	for id := range remaining {
+		s.mu.Lock()
+		order, ok := s.tracked[id]
+		if ok {
+			delete(s.tracked, id)
+		}
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+		s.emit(OrderEvent{Type: OrderCancelled, Order: &bitx.Order{Id: id, State: order}})
+	}
+	return nil
+}
+
+func (s *UserDataStream) untrack(id string) {
+	s.mu.Lock()
+	delete(s.tracked, id)
+	s.mu.Unlock()
+}
+
+func (s *UserDataStream) emit(event OrderEvent) {
+	select {
+	case s.events <- event:
+	default:
+		// Backpressure-aware: drop rather than block a slow consumer.
+	}
+}
+
+func (s *UserDataStream) wait(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}